@@ -0,0 +1,173 @@
+// Command bench runs vex's fast time-parsing/formatting paths side by
+// side with their stdlib equivalents and reports bytes/op and
+// allocs/op alongside ns/op, replacing the old bench_go timing loop
+// that only measured wall-clock time.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	vex "github.com/meftunca/vex/vex-libs/std/time/native"
+)
+
+// result is one row of the report: a single testing.B run reduced to
+// the numbers CI cares about.
+type result struct {
+	Name        string  `json:"name"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	BytesPerOp  int64   `json:"bytes_per_op"`
+	AllocsPerOp int64   `json:"allocs_per_op"`
+}
+
+func run(name string, fn func(b *testing.B)) result {
+	r := testing.Benchmark(fn)
+	return result{
+		Name:        name,
+		NsPerOp:     float64(r.T.Nanoseconds()) / float64(r.N),
+		BytesPerOp:  int64(r.MemBytes) / int64(r.N),
+		AllocsPerOp: int64(r.MemAllocs) / int64(r.N),
+	}
+}
+
+func main() {
+	jsonOut := flag.Bool("json", false, "emit results as JSON")
+	flag.Parse()
+
+	const (
+		utcStr      = "2024-11-07T12:34:56Z"
+		offsetStr   = "2024-11-07T12:34:56-08:00"
+		frac1Str    = "2024-11-07T12:34:56.1Z"
+		frac3Str    = "2024-11-07T12:34:56.123Z"
+		frac6Str    = "2024-11-07T12:34:56.123456Z"
+		frac9Str    = "2024-11-07T12:34:56.123456789Z"
+		rfc1123zStr = "Thu, 07 Nov 2024 12:34:56 +0000"
+	)
+
+	tm, _ := time.Parse(time.RFC3339Nano, frac9Str)
+	buf := make([]byte, 0, 64)
+
+	results := []result{
+		run("stdlib/Parse/RFC3339-Z", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				time.Parse(time.RFC3339Nano, utcStr)
+			}
+		}),
+		run("vex/Parse3339/Z", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				vex.Parse3339(utcStr)
+			}
+		}),
+		run("stdlib/Parse/RFC3339-offset", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				time.Parse(time.RFC3339Nano, offsetStr)
+			}
+		}),
+		run("vex/Parse3339/offset", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				vex.Parse3339(offsetStr)
+			}
+		}),
+		run("stdlib/Parse/RFC3339Nano-frac1", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				time.Parse(time.RFC3339Nano, frac1Str)
+			}
+		}),
+		run("vex/Parse3339/frac1", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				vex.Parse3339(frac1Str)
+			}
+		}),
+		run("stdlib/Parse/RFC3339Nano-frac3", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				time.Parse(time.RFC3339Nano, frac3Str)
+			}
+		}),
+		run("vex/Parse3339/frac3", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				vex.Parse3339(frac3Str)
+			}
+		}),
+		run("stdlib/Parse/RFC3339Nano-frac6", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				time.Parse(time.RFC3339Nano, frac6Str)
+			}
+		}),
+		run("vex/Parse3339/frac6", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				vex.Parse3339(frac6Str)
+			}
+		}),
+		run("stdlib/Parse/RFC3339Nano-frac9", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				time.Parse(time.RFC3339Nano, frac9Str)
+			}
+		}),
+		run("vex/Parse3339/frac9", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				vex.Parse3339(frac9Str)
+			}
+		}),
+		run("stdlib/Parse/RFC1123Z", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				time.Parse(time.RFC1123Z, rfc1123zStr)
+			}
+		}),
+		run("vex/Layout/RFC1123Z-Parse", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				vex.RFC1123Z.Parse(rfc1123zStr)
+			}
+		}),
+		run("stdlib/Format/RFC3339Nano", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				tm.Format(time.RFC3339Nano)
+			}
+		}),
+		run("vex/FormatRFC3339Nano", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				vex.FormatRFC3339Nano(tm)
+			}
+		}),
+		run("vex/AppendRFC3339Nano-reused-buffer", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buf = vex.AppendRFC3339Nano(buf[:0], tm)
+			}
+		}),
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Printf("%-38s %12s %10s %10s\n", "benchmark", "ns/op", "B/op", "allocs/op")
+	for _, r := range results {
+		fmt.Printf("%-38s %12.1f %10d %10d\n", r.Name, r.NsPerOp, r.BytesPerOp, r.AllocsPerOp)
+	}
+}
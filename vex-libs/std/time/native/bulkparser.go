@@ -0,0 +1,128 @@
+package vex
+
+import (
+	"container/list"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// zoneShardCount is the number of sync.Map shards BulkParser spreads its
+// offset cache across. Log-tailing pipelines can have many goroutines
+// resolving the same handful of offsets concurrently; sharding keeps
+// that off a single sync.Map's hot path the way Parse3339's package-wide
+// cachedZone would otherwise become under that load.
+const zoneShardCount = 16
+
+// shardedZoneCache is a zone cache split across zoneShardCount
+// independent sync.Maps, keyed by an FNV hash of the offset string.
+type shardedZoneCache struct {
+	shards [zoneShardCount]sync.Map // map[string]*time.Location
+}
+
+func (c *shardedZoneCache) get(offset string, offsetSeconds int) *time.Location {
+	shard := &c.shards[shardFor(offset)]
+	if v, ok := shard.Load(offset); ok {
+		return v.(*time.Location)
+	}
+	loc := time.FixedZone(offset, offsetSeconds)
+	actual, _ := shard.LoadOrStore(offset, loc)
+	return actual.(*time.Location)
+}
+
+func shardFor(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return h.Sum32() % zoneShardCount
+}
+
+// bulkZoneCache is shared by every BulkParser: offset suffixes repeat
+// heavily across a log stream, so there is no benefit to per-instance
+// isolation and every benefit to sharing the warmed cache.
+var bulkZoneCache = &shardedZoneCache{}
+
+// BulkParser is an RFC3339/RFC3339Nano parser tuned for ingestion
+// pipelines that re-parse the same handful of zone suffixes and,
+// within a bursty window, the same exact timestamp string millions of
+// times. It layers a small per-instance LRU of exact-match strings on
+// top of the sharded zone-offset cache every BulkParser shares.
+//
+// The LRU is not internally synchronized -- "the string LRU can be
+// per-instance so callers control locking" means exactly that: give
+// each concurrent worker its own BulkParser (cheap; the expensive zone
+// cache is shared package-wide) rather than sharing one across
+// goroutines.
+type BulkParser struct {
+	cap     int
+	entries map[string]*list.Element
+	order   *list.List // front = most recently used
+}
+
+type bulkEntry struct {
+	key string
+	t   time.Time
+}
+
+// NewBulkParser returns a BulkParser whose exact-match string cache
+// holds up to cacheSize entries. cacheSize <= 0 disables the string
+// cache; every call still benefits from the shared zone cache.
+func NewBulkParser(cacheSize int) *BulkParser {
+	p := &BulkParser{cap: cacheSize, order: list.New()}
+	if cacheSize > 0 {
+		p.entries = make(map[string]*list.Element, cacheSize)
+	}
+	return p
+}
+
+// ParseInto parses s and writes the result to *dst, reusing a cached
+// decode if s exactly matches a recently parsed string.
+func (p *BulkParser) ParseInto(dst *time.Time, s []byte) error {
+	if p.cap > 0 {
+		if el, ok := p.entries[string(s)]; ok {
+			p.order.MoveToFront(el)
+			*dst = el.Value.(*bulkEntry).t
+			return nil
+		}
+	}
+
+	key := string(s)
+	f, err := decode3339(key)
+	if err != nil {
+		return err
+	}
+	loc := time.UTC
+	if !f.isUTC {
+		loc = bulkZoneCache.get(f.offset, f.offsetSeconds)
+	}
+	t := time.Date(f.year, time.Month(f.month), f.day, f.hour, f.min, f.sec, f.nsec, loc)
+
+	if p.cap > 0 {
+		el := p.order.PushFront(&bulkEntry{key: key, t: t})
+		p.entries[key] = el
+		if p.order.Len() > p.cap {
+			oldest := p.order.Back()
+			p.order.Remove(oldest)
+			delete(p.entries, oldest.Value.(*bulkEntry).key)
+		}
+	}
+
+	*dst = t
+	return nil
+}
+
+// ParseMany parses each element of in into the corresponding element of
+// out, stopping at the first error. It returns the number of entries
+// successfully parsed; on error that count is the index of the
+// offending entry in in.
+func (p *BulkParser) ParseMany(out []time.Time, in [][]byte) (int, error) {
+	if len(out) < len(in) {
+		return 0, fmt.Errorf("vex: ParseMany: out has len %d, need at least %d", len(out), len(in))
+	}
+	for i, s := range in {
+		if err := p.ParseInto(&out[i], s); err != nil {
+			return i, err
+		}
+	}
+	return len(in), nil
+}
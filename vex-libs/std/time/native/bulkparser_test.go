@@ -0,0 +1,100 @@
+package vex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBulkParserParseInto(t *testing.T) {
+	p := NewBulkParser(4)
+	var got time.Time
+	const s = "2024-11-07T12:34:56.123Z"
+	if err := p.ParseInto(&got, []byte(s)); err != nil {
+		t.Fatalf("ParseInto: %v", err)
+	}
+	want, _ := time.Parse(time.RFC3339Nano, s)
+	if !got.Equal(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	// Repeated parse of the same exact string should hit the LRU and
+	// still produce the same result.
+	var got2 time.Time
+	if err := p.ParseInto(&got2, []byte(s)); err != nil {
+		t.Fatalf("ParseInto (cached): %v", err)
+	}
+	if !got2.Equal(want) {
+		t.Fatalf("cached parse = %v, want %v", got2, want)
+	}
+}
+
+func TestBulkParserParseIntoRejectsMalformed(t *testing.T) {
+	p := NewBulkParser(4)
+	var got time.Time
+	if err := p.ParseInto(&got, []byte("not-a-timestamp")); err == nil {
+		t.Fatal("expected error for malformed input")
+	}
+}
+
+func TestBulkParserParseMany(t *testing.T) {
+	p := NewBulkParser(8)
+	in := [][]byte{
+		[]byte("2024-11-07T12:34:56Z"),
+		[]byte("2024-11-07T12:34:57-08:00"),
+		[]byte("2024-11-07T12:34:56Z"), // duplicate, exercises the LRU
+	}
+	out := make([]time.Time, len(in))
+	n, err := p.ParseMany(out, in)
+	if err != nil {
+		t.Fatalf("ParseMany: %v", err)
+	}
+	if n != len(in) {
+		t.Fatalf("ParseMany returned %d, want %d", n, len(in))
+	}
+	for i, s := range in {
+		want, _ := time.Parse(time.RFC3339Nano, string(s))
+		if !out[i].Equal(want) {
+			t.Errorf("out[%d] = %v, want %v", i, out[i], want)
+		}
+	}
+}
+
+func TestBulkParserParseManyShortOut(t *testing.T) {
+	p := NewBulkParser(4)
+	in := [][]byte{[]byte("2024-11-07T12:34:56Z"), []byte("2024-11-07T12:34:57Z")}
+	out := make([]time.Time, 1)
+	if _, err := p.ParseMany(out, in); err == nil {
+		t.Fatal("expected error when out is shorter than in")
+	}
+}
+
+func TestBulkParserEvictsOldest(t *testing.T) {
+	p := NewBulkParser(2)
+	var tmp time.Time
+	strs := []string{
+		"2024-01-01T00:00:00Z",
+		"2024-01-02T00:00:00Z",
+		"2024-01-03T00:00:00Z",
+	}
+	for _, s := range strs {
+		if err := p.ParseInto(&tmp, []byte(s)); err != nil {
+			t.Fatalf("ParseInto(%q): %v", s, err)
+		}
+	}
+	if len(p.entries) != 2 {
+		t.Fatalf("cache has %d entries, want 2", len(p.entries))
+	}
+	if _, ok := p.entries[strs[0]]; ok {
+		t.Fatalf("oldest entry %q should have been evicted", strs[0])
+	}
+}
+
+func BenchmarkBulkParserParseInto(b *testing.B) {
+	p := NewBulkParser(1024)
+	s := []byte("2024-11-07T12:34:56.123456789Z")
+	var dst time.Time
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p.ParseInto(&dst, s)
+	}
+}
@@ -0,0 +1,110 @@
+package vex
+
+import "time"
+
+// twoDigits is a lookup table of the two-ASCII-digit representation of
+// 0-99, avoiding the division/modulo strconv would otherwise need per
+// field when formatting HH, MM, SS, mm and dd.
+var twoDigits = [100][2]byte{}
+
+func init() {
+	for i := 0; i < 100; i++ {
+		twoDigits[i] = [2]byte{byte('0' + i/10), byte('0' + i%10)}
+	}
+}
+
+func appendTwoDigits(dst []byte, v int) []byte {
+	d := twoDigits[v]
+	return append(dst, d[0], d[1])
+}
+
+// appendFourDigits appends a year as four zero-padded digits, the same as
+// time.Format does for "2006". Years outside 0-9999 can't be represented
+// in four digits -- like time.Format's appendInt, this falls back to a
+// '-' sign plus however many digits the magnitude needs (e.g. "-0001" for
+// 1 BC, "10000" for year 10000) instead of truncating or panicking on the
+// twoDigits table.
+func appendFourDigits(dst []byte, v int) []byte {
+	if v < 0 {
+		dst = append(dst, '-')
+		v = -v
+	}
+	if v < 10000 {
+		dst = appendTwoDigits(dst, v/100)
+		return appendTwoDigits(dst, v%100)
+	}
+	var buf [20]byte
+	i := len(buf)
+	for v >= 10 {
+		i--
+		buf[i] = byte('0' + v%10)
+		v /= 10
+	}
+	i--
+	buf[i] = byte('0' + v)
+	return append(dst, buf[i:]...)
+}
+
+// AppendRFC3339Nano appends the RFC3339Nano representation of t to dst
+// and returns the extended buffer, without allocating beyond what
+// growing dst requires. It bypasses time.Format's layout interpreter
+// entirely: the fields come from t.Date()/t.Clock()/t.Nanosecond(), and
+// each is written directly as fixed-width zero-padded digits.
+func AppendRFC3339Nano(dst []byte, t time.Time) []byte {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	nsec := t.Nanosecond()
+
+	dst = appendFourDigits(dst, year)
+	dst = append(dst, '-')
+	dst = appendTwoDigits(dst, int(month))
+	dst = append(dst, '-')
+	dst = appendTwoDigits(dst, day)
+	dst = append(dst, 'T')
+	dst = appendTwoDigits(dst, hour)
+	dst = append(dst, ':')
+	dst = appendTwoDigits(dst, min)
+	dst = append(dst, ':')
+	dst = appendTwoDigits(dst, sec)
+
+	if nsec != 0 {
+		dst = append(dst, '.')
+		// Nine digits of nanoseconds, then trim trailing zeros to match
+		// time.Format's RFC3339Nano behavior.
+		var buf [9]byte
+		for i := 8; i >= 0; i-- {
+			buf[i] = byte('0' + nsec%10)
+			nsec /= 10
+		}
+		end := 9
+		for end > 0 && buf[end-1] == '0' {
+			end--
+		}
+		dst = append(dst, buf[:end]...)
+	}
+
+	_, offset := t.Zone()
+	if offset == 0 {
+		return append(dst, 'Z')
+	}
+
+	sign := byte('+')
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	dst = append(dst, sign)
+	dst = appendTwoDigits(dst, offset/3600)
+	dst = append(dst, ':')
+	dst = appendTwoDigits(dst, (offset%3600)/60)
+	return dst
+}
+
+// FormatRFC3339Nano formats t as RFC3339Nano using the same zero-padded
+// fixed-width field writers as AppendRFC3339Nano. Callers in hot loops
+// should prefer AppendRFC3339Nano with a reused buffer; this is the
+// convenience entry point for everyone else.
+func FormatRFC3339Nano(t time.Time) string {
+	var buf [40]byte
+	return string(AppendRFC3339Nano(buf[:0], t))
+}
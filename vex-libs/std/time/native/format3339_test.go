@@ -0,0 +1,89 @@
+package vex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatRFC3339NanoMatchesStdlib(t *testing.T) {
+	utc := time.Date(2024, 11, 7, 12, 34, 56, 123456789, time.UTC)
+	offset := time.FixedZone("+02:00", 2*3600)
+	cases := []time.Time{
+		utc,
+		time.Date(2024, 11, 7, 12, 34, 56, 0, time.UTC),
+		time.Date(2024, 11, 7, 12, 34, 56, 100000000, time.UTC),
+		time.Date(2024, 11, 7, 12, 34, 56, 123456789, offset),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.FixedZone("-08:00", -8*3600)),
+	}
+	for _, tm := range cases {
+		want := tm.Format(time.RFC3339Nano)
+		got := FormatRFC3339Nano(tm)
+		if got != want {
+			t.Errorf("FormatRFC3339Nano(%v) = %q, want %q", tm, got, want)
+		}
+	}
+}
+
+func TestFormatRFC3339NanoZeroOffsetNonUTCLocation(t *testing.T) {
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("+00:00", 0))
+	want := tm.Format(time.RFC3339Nano)
+	got := FormatRFC3339Nano(tm)
+	if got != want {
+		t.Errorf("FormatRFC3339Nano(%v) = %q, want %q", tm, got, want)
+	}
+}
+
+func TestParse3339FormatRFC3339NanoRoundTripZeroOffset(t *testing.T) {
+	s := "2020-01-02T03:04:05+00:00"
+	tm, err := Parse3339(s)
+	if err != nil {
+		t.Fatalf("Parse3339(%q): %v", s, err)
+	}
+	want := tm.Format(time.RFC3339Nano)
+	got := FormatRFC3339Nano(tm)
+	if got != want {
+		t.Errorf("FormatRFC3339Nano(Parse3339(%q)) = %q, want %q", s, got, want)
+	}
+}
+
+func TestFormatRFC3339NanoOutOfRangeYears(t *testing.T) {
+	cases := []time.Time{
+		time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(123456, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(-1, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, tm := range cases {
+		want := tm.Format(time.RFC3339Nano)
+		got := FormatRFC3339Nano(tm)
+		if got != want {
+			t.Errorf("FormatRFC3339Nano(%v) = %q, want %q", tm, got, want)
+		}
+	}
+}
+
+func TestAppendRFC3339NanoReusesBuffer(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	tm := time.Date(2024, 11, 7, 12, 34, 56, 123456789, time.UTC)
+	buf = AppendRFC3339Nano(buf, tm)
+	if string(buf) != tm.Format(time.RFC3339Nano) {
+		t.Fatalf("got %q, want %q", buf, tm.Format(time.RFC3339Nano))
+	}
+}
+
+func BenchmarkFormatRFC3339Nano(b *testing.B) {
+	tm := time.Date(2024, 11, 7, 12, 34, 56, 123456789, time.UTC)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		FormatRFC3339Nano(tm)
+	}
+}
+
+func BenchmarkAppendRFC3339Nano(b *testing.B) {
+	tm := time.Date(2024, 11, 7, 12, 34, 56, 123456789, time.UTC)
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = AppendRFC3339Nano(buf[:0], tm)
+	}
+}
@@ -0,0 +1,191 @@
+package vex
+
+import "fmt"
+
+// tokenKind identifies one piece of a compiled layout: either a literal
+// run of bytes copied verbatim, or one typed field (year4, month2, ...)
+// that Parse/AppendFormat know how to decode or emit directly.
+type tokenKind int
+
+const (
+	tokLiteral     tokenKind = iota
+	tokLongYear              // "2006"
+	tokYear                  // "06"
+	tokLongMonth             // "January"
+	tokMonth                 // "Jan"
+	tokNumMonth              // "1"
+	tokZeroMonth             // "01"
+	tokLongWeekday           // "Monday"
+	tokWeekday               // "Mon"
+	tokDay                   // "2"
+	tokUnderDay              // "_2"
+	tokZeroDay               // "02"
+	tokHour24                // "15"
+	tokHour12                // "3"
+	tokZeroHour12            // "03"
+	tokMinute                // "4"
+	tokZeroMinute            // "04"
+	tokSecond                // "5"
+	tokZeroSecond            // "05"
+	tokPM                    // "PM"
+	tokpm                    // "pm"
+	tokTZName                // "MST"
+	tokTZNumColon            // "-07:00" / "Z07:00"
+	tokTZNum                 // "-0700" / "Z0700"
+	tokFracSecond            // ".000" / ".999..."
+)
+
+// token is one element of a compiled Layout.
+type token struct {
+	kind tokenKind
+	lit  string // tokLiteral: the raw bytes; tokTZNum*: "Z" if zulu-capable
+
+	// tokFracSecond only: number of digits, and whether trailing zeros
+	// are trimmed (the Go ".9" family) rather than zero-padded (".0").
+	fracWidth int
+	fracTrim  bool
+}
+
+// Layout is a layout string compiled once into a token slice, so that
+// repeated Parse/AppendFormat calls dispatch through precomputed tokens
+// instead of re-scanning the layout on every call the way time.Parse and
+// time.Time.Format do.
+type Layout struct {
+	raw    string
+	tokens []token
+}
+
+// Compile parses a Go reference-time layout string (the same grammar
+// accepted by time.Parse and time.Time.Format, built around the
+// reference time "Mon Jan 2 15:04:05 MST 2006") into a *Layout.
+func Compile(layout string) (*Layout, error) {
+	l := &Layout{raw: layout}
+	rest := layout
+	var lit []byte
+	flushLit := func() {
+		if len(lit) > 0 {
+			l.tokens = append(l.tokens, token{kind: tokLiteral, lit: string(lit)})
+			lit = lit[:0]
+		}
+	}
+
+	for len(rest) > 0 {
+		prefixLen, tok, ok := nextStdChunk(rest)
+		if !ok {
+			lit = append(lit, rest[0])
+			rest = rest[1:]
+			continue
+		}
+		flushLit()
+		l.tokens = append(l.tokens, tok)
+		rest = rest[prefixLen:]
+	}
+	flushLit()
+	return l, nil
+}
+
+// MustCompile is like Compile but panics on error; intended for
+// package-level Layout variables initialized from literal strings.
+func MustCompile(layout string) *Layout {
+	l, err := Compile(layout)
+	if err != nil {
+		panic(fmt.Sprintf("vex: MustCompile(%q): %v", layout, err))
+	}
+	return l
+}
+
+// nextStdChunk finds the reference-layout element at the start of s (if
+// any), returning how many bytes of s it consumed and the token to
+// record for it. It mirrors the matching table the standard library
+// uses for "Mon Jan 2 15:04:05 MST 2006" so Compile accepts exactly the
+// layouts time.Parse and time.Format do.
+func nextStdChunk(s string) (n int, tok token, ok bool) {
+	switch s[0] {
+	case 'J': // January, Jan
+		if len(s) >= 7 && s[:7] == "January" {
+			return 7, token{kind: tokLongMonth}, true
+		}
+		if len(s) >= 3 && s[:3] == "Jan" {
+			return 3, token{kind: tokMonth}, true
+		}
+	case 'M': // Monday, Mon, MST
+		if len(s) >= 6 && s[:6] == "Monday" {
+			return 6, token{kind: tokLongWeekday}, true
+		}
+		if len(s) >= 3 && s[:3] == "Mon" {
+			return 3, token{kind: tokWeekday}, true
+		}
+		if len(s) >= 3 && s[:3] == "MST" {
+			return 3, token{kind: tokTZName}, true
+		}
+	case '0': // 01, 02, 03, 04, 05, 06
+		if len(s) >= 2 {
+			switch s[1] {
+			case '1':
+				return 2, token{kind: tokZeroMonth}, true
+			case '2':
+				return 2, token{kind: tokZeroDay}, true
+			case '3':
+				return 2, token{kind: tokZeroHour12}, true
+			case '4':
+				return 2, token{kind: tokZeroMinute}, true
+			case '5':
+				return 2, token{kind: tokZeroSecond}, true
+			case '6':
+				return 2, token{kind: tokYear}, true
+			}
+		}
+	case '1': // 15, 1
+		if len(s) >= 2 && s[1] == '5' {
+			return 2, token{kind: tokHour24}, true
+		}
+		return 1, token{kind: tokNumMonth}, true
+	case '2': // 2006, 2
+		if len(s) >= 4 && s[:4] == "2006" {
+			return 4, token{kind: tokLongYear}, true
+		}
+		return 1, token{kind: tokDay}, true
+	case '_': // _2
+		if len(s) >= 2 && s[1] == '2' {
+			return 2, token{kind: tokUnderDay}, true
+		}
+	case '3':
+		return 1, token{kind: tokHour12}, true
+	case '4':
+		return 1, token{kind: tokMinute}, true
+	case '5':
+		return 1, token{kind: tokSecond}, true
+	case 'P':
+		if len(s) >= 2 && s[1] == 'M' {
+			return 2, token{kind: tokPM}, true
+		}
+	case 'p':
+		if len(s) >= 2 && s[1] == 'm' {
+			return 2, token{kind: tokpm}, true
+		}
+	case '-': // -0700, -07:00
+		if len(s) >= 6 && s[:6] == "-07:00" {
+			return 6, token{kind: tokTZNumColon, lit: "-"}, true
+		}
+		if len(s) >= 5 && s[:5] == "-0700" {
+			return 5, token{kind: tokTZNum, lit: "-"}, true
+		}
+	case 'Z': // Z0700, Z07:00
+		if len(s) >= 6 && s[:6] == "Z07:00" {
+			return 6, token{kind: tokTZNumColon, lit: "Z"}, true
+		}
+		if len(s) >= 5 && s[:5] == "Z0700" {
+			return 5, token{kind: tokTZNum, lit: "Z"}, true
+		}
+	case '.': // .000..., .999...
+		if len(s) >= 2 && (s[1] == '0' || s[1] == '9') {
+			ch := s[1]
+			n := 1
+			for n < len(s) && s[n] == ch {
+				n++
+			}
+			return n, token{kind: tokFracSecond, fracWidth: n - 1, fracTrim: ch == '9'}, true
+		}
+	}
+	return 0, token{}, false
+}
@@ -0,0 +1,161 @@
+package vex
+
+import "time"
+
+var longMonthNames = [...]string{
+	"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December",
+}
+
+var shortMonthNames = [...]string{
+	"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+	"Jul", "Aug", "Sep", "Oct", "Nov", "Dec",
+}
+
+var longWeekdayNames = [...]string{
+	"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+}
+
+var shortWeekdayNames = [...]string{
+	"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat",
+}
+
+// AppendFormat appends the textual representation of t, formatted
+// according to l, to dst and returns the extended buffer. Because l was
+// compiled once by Compile, this dispatches through a precomputed token
+// slice instead of re-tokenizing the layout on every call the way
+// time.Time.Format does.
+func (l *Layout) AppendFormat(dst []byte, t time.Time) []byte {
+	year, month, day := t.Date()
+	hour, minute, second := t.Clock()
+	weekday := t.Weekday()
+
+	for _, tok := range l.tokens {
+		switch tok.kind {
+		case tokLiteral:
+			dst = append(dst, tok.lit...)
+		case tokLongYear:
+			dst = appendFourDigits(dst, year)
+		case tokYear:
+			dst = appendTwoDigits(dst, year%100)
+		case tokLongMonth:
+			dst = append(dst, longMonthNames[month-1]...)
+		case tokMonth:
+			dst = append(dst, shortMonthNames[month-1]...)
+		case tokNumMonth:
+			dst = appendIntNoPad(dst, int(month))
+		case tokZeroMonth:
+			dst = appendTwoDigits(dst, int(month))
+		case tokLongWeekday:
+			dst = append(dst, longWeekdayNames[weekday]...)
+		case tokWeekday:
+			dst = append(dst, shortWeekdayNames[weekday]...)
+		case tokDay:
+			dst = appendIntNoPad(dst, day)
+		case tokUnderDay:
+			if day < 10 {
+				dst = append(dst, ' ')
+				dst = append(dst, byte('0'+day))
+			} else {
+				dst = appendIntNoPad(dst, day)
+			}
+		case tokZeroDay:
+			dst = appendTwoDigits(dst, day)
+		case tokHour24:
+			dst = appendTwoDigits(dst, hour)
+		case tokHour12:
+			dst = appendIntNoPad(dst, hour12(hour))
+		case tokZeroHour12:
+			dst = appendTwoDigits(dst, hour12(hour))
+		case tokMinute:
+			dst = appendIntNoPad(dst, minute)
+		case tokZeroMinute:
+			dst = appendTwoDigits(dst, minute)
+		case tokSecond:
+			dst = appendIntNoPad(dst, second)
+		case tokZeroSecond:
+			dst = appendTwoDigits(dst, second)
+		case tokPM:
+			if hour >= 12 {
+				dst = append(dst, "PM"...)
+			} else {
+				dst = append(dst, "AM"...)
+			}
+		case tokpm:
+			if hour >= 12 {
+				dst = append(dst, "pm"...)
+			} else {
+				dst = append(dst, "am"...)
+			}
+		case tokTZName:
+			name, _ := t.Zone()
+			dst = append(dst, name...)
+		case tokTZNumColon, tokTZNum:
+			dst = appendNumTZ(dst, t, tok.kind == tokTZNumColon, tok.lit == "Z")
+		case tokFracSecond:
+			dst = appendFracSecond(dst, t.Nanosecond(), tok.fracWidth, tok.fracTrim)
+		}
+	}
+	return dst
+}
+
+// Format returns the textual representation of t using l, equivalent to
+// string(l.AppendFormat(nil, t)).
+func (l *Layout) Format(t time.Time) string {
+	return string(l.AppendFormat(make([]byte, 0, 32), t))
+}
+
+func hour12(hour int) int {
+	h := hour % 12
+	if h == 0 {
+		h = 12
+	}
+	return h
+}
+
+func appendIntNoPad(dst []byte, v int) []byte {
+	if v < 10 {
+		return append(dst, byte('0'+v))
+	}
+	return appendTwoDigits(dst, v)
+}
+
+func appendNumTZ(dst []byte, t time.Time, colon, zuluCapable bool) []byte {
+	_, offset := t.Zone()
+	if zuluCapable && offset == 0 {
+		return append(dst, 'Z')
+	}
+	sign := byte('+')
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	dst = append(dst, sign)
+	dst = appendTwoDigits(dst, offset/3600)
+	if colon {
+		dst = append(dst, ':')
+	}
+	return appendTwoDigits(dst, (offset%3600)/60)
+}
+
+func appendFracSecond(dst []byte, nsec, width int, trim bool) []byte {
+	var buf [9]byte
+	n := nsec
+	for i := 8; i >= 0; i-- {
+		buf[i] = byte('0' + n%10)
+		n /= 10
+	}
+	digits := buf[:width]
+	if trim {
+		end := width
+		for end > 0 && digits[end-1] == '0' {
+			end--
+		}
+		if end == 0 {
+			return dst
+		}
+		digits = digits[:end]
+	}
+	dst = append(dst, '.')
+	return append(dst, digits...)
+}
@@ -0,0 +1,315 @@
+package vex
+
+import "time"
+
+// Parse parses s according to l, the compiled form of a Go reference-time
+// layout, producing the same result time.Parse(l.raw, s) would for any
+// input this Layout's token set supports. Zone-abbreviation tokens
+// (literal "MST"-style names, as opposed to numeric offsets) carry no
+// offset information of their own -- like time.Parse, this records the
+// abbreviation but assumes a zero offset unless a numeric offset token
+// is also present in the layout.
+func (l *Layout) Parse(s string) (time.Time, error) {
+	b := []byte(s)
+
+	year, month, day := 0, time.January, 1
+	hour, minute, second, nsec := 0, 0, 0, 0
+	offsetSeconds := 0
+	haveOffset := false
+	pm := false
+	havePM := false
+	zoneName := ""
+
+	for _, tok := range l.tokens {
+		switch tok.kind {
+		case tokLiteral:
+			if len(b) < len(tok.lit) || string(b[:len(tok.lit)]) != tok.lit {
+				return time.Time{}, parseErr(s, "layout mismatch at literal "+tok.lit)
+			}
+			b = b[len(tok.lit):]
+		case tokLongYear:
+			if len(b) < 4 {
+				return time.Time{}, parseErr(s, "invalid year")
+			}
+			v, ok := digit4(b[:4])
+			if !ok {
+				return time.Time{}, parseErr(s, "invalid year")
+			}
+			year, b = v, b[4:]
+		case tokYear:
+			v, rest, err := parseUint(b, 2, 2)
+			if err != nil {
+				return time.Time{}, parseErr(s, "invalid year")
+			}
+			if v < 69 {
+				year = 2000 + v
+			} else {
+				year = 1900 + v
+			}
+			b = rest
+		case tokLongMonth:
+			idx, rest, err := matchName(b, longMonthNames[:])
+			if err != nil {
+				return time.Time{}, parseErr(s, "invalid month")
+			}
+			month, b = time.Month(idx+1), rest
+		case tokMonth:
+			idx, rest, err := matchName(b, shortMonthNames[:])
+			if err != nil {
+				return time.Time{}, parseErr(s, "invalid month")
+			}
+			month, b = time.Month(idx+1), rest
+		case tokNumMonth, tokZeroMonth:
+			v, rest, err := parseUint(b, 1, 2)
+			if err != nil || v < 1 || v > 12 {
+				return time.Time{}, parseErr(s, "invalid month")
+			}
+			month, b = time.Month(v), rest
+		case tokLongWeekday:
+			_, rest, err := matchName(b, longWeekdayNames[:])
+			if err != nil {
+				return time.Time{}, parseErr(s, "invalid weekday")
+			}
+			b = rest
+		case tokWeekday:
+			_, rest, err := matchName(b, shortWeekdayNames[:])
+			if err != nil {
+				return time.Time{}, parseErr(s, "invalid weekday")
+			}
+			b = rest
+		case tokDay, tokZeroDay:
+			v, rest, err := parseUint(b, 1, 2)
+			if err != nil || v < 1 || v > 31 {
+				return time.Time{}, parseErr(s, "invalid day")
+			}
+			day, b = v, rest
+		case tokUnderDay:
+			if len(b) > 0 && b[0] == ' ' {
+				b = b[1:]
+			}
+			v, rest, err := parseUint(b, 1, 2)
+			if err != nil || v < 1 || v > 31 {
+				return time.Time{}, parseErr(s, "invalid day")
+			}
+			day, b = v, rest
+		case tokHour24:
+			v, rest, err := parseUint(b, 1, 2)
+			if err != nil || v > 23 {
+				return time.Time{}, parseErr(s, "invalid hour")
+			}
+			hour, b = v, rest
+		case tokHour12, tokZeroHour12:
+			v, rest, err := parseUint(b, 1, 2)
+			if err != nil || v < 1 || v > 12 {
+				return time.Time{}, parseErr(s, "invalid hour")
+			}
+			hour, b = v%12, rest
+		case tokMinute, tokZeroMinute:
+			v, rest, err := parseUint(b, 1, 2)
+			if err != nil || v > 59 {
+				return time.Time{}, parseErr(s, "invalid minute")
+			}
+			minute, b = v, rest
+		case tokSecond, tokZeroSecond:
+			v, rest, err := parseUint(b, 1, 2)
+			if err != nil || v > 59 {
+				return time.Time{}, parseErr(s, "invalid second")
+			}
+			second, b = v, rest
+		case tokPM, tokpm:
+			if len(b) < 2 {
+				return time.Time{}, parseErr(s, "invalid AM/PM")
+			}
+			switch string(b[:2]) {
+			case "PM", "pm":
+				pm = true
+			case "AM", "am":
+				pm = false
+			default:
+				return time.Time{}, parseErr(s, "invalid AM/PM")
+			}
+			havePM, b = true, b[2:]
+		case tokTZName:
+			n, ok := scanZoneName(b)
+			if !ok {
+				return time.Time{}, parseErr(s, "invalid zone name")
+			}
+			zoneName, b = string(b[:n]), b[n:]
+		case tokTZNumColon, tokTZNum:
+			off, rest, err := parseNumTZ(b, tok.kind == tokTZNumColon, tok.lit == "Z")
+			if err != nil {
+				return time.Time{}, err
+			}
+			offsetSeconds, haveOffset, b = off, true, rest
+		case tokFracSecond:
+			if len(b) > 0 && b[0] == '.' {
+				start := 1
+				i := start
+				for i < len(b) && b[i] >= '0' && b[i] <= '9' {
+					i++
+				}
+				digits := b[start:i]
+				n := 0
+				for j := 0; j < 9; j++ {
+					if j < len(digits) {
+						n = n*10 + int(digits[j]-'0')
+					} else {
+						n *= 10
+					}
+				}
+				nsec, b = n, b[i:]
+			}
+		}
+	}
+
+	if len(b) != 0 {
+		return time.Time{}, parseErr(s, "extra characters after timestamp")
+	}
+	if day > daysInMonth(year, int(month)) {
+		return time.Time{}, parseErr(s, "day out of range")
+	}
+	if havePM {
+		if pm && hour != 12 {
+			hour += 12
+		} else if !pm && hour == 12 {
+			hour = 0
+		}
+	}
+
+	var loc *time.Location
+	switch {
+	case haveOffset:
+		if offsetSeconds == 0 && zoneName == "" {
+			loc = time.UTC
+		} else {
+			loc = cachedZone(zoneOffsetKey(zoneName, offsetSeconds), offsetSeconds)
+		}
+	case zoneName != "":
+		loc = time.FixedZone(zoneName, 0)
+	default:
+		loc = time.UTC
+	}
+
+	return time.Date(year, month, day, hour, minute, second, nsec, loc), nil
+}
+
+// scanZoneName finds a zone abbreviation (the "MST" token) at the start
+// of b and returns its length, following the same rule time.Parse uses:
+// a run of 3 upper-case letters, or 4-5 upper-case letters ending in 'T'
+// (plus the "WITA" special case), or a "GMT" optionally followed by a
+// signed numeric offset. Zone abbreviations are otherwise unpredictable,
+// so -- like time.Parse -- this can't reject everything that isn't one.
+func scanZoneName(b []byte) (int, bool) {
+	if len(b) < 3 {
+		return 0, false
+	}
+	if string(b[:3]) == "GMT" {
+		n := 3
+		rest := b[3:]
+		if len(rest) > 0 && (rest[0] == '+' || rest[0] == '-') {
+			i := 1
+			for i < len(rest) && ((rest[i] >= '0' && rest[i] <= '9') || rest[i] == ':') {
+				i++
+			}
+			if i > 1 {
+				n += i
+			}
+		}
+		return n, true
+	}
+
+	nUpper := 0
+	for nUpper < 6 && nUpper < len(b) {
+		if c := b[nUpper]; c < 'A' || c > 'Z' {
+			break
+		}
+		nUpper++
+	}
+	switch nUpper {
+	case 3:
+		return 3, true
+	case 4:
+		if b[3] == 'T' || string(b[:4]) == "WITA" {
+			return 4, true
+		}
+	case 5:
+		if b[4] == 'T' {
+			return 5, true
+		}
+	}
+	return 0, false
+}
+
+func zoneOffsetKey(name string, offsetSeconds int) string {
+	if name != "" {
+		return name
+	}
+	sign := byte('+')
+	off := offsetSeconds
+	if off < 0 {
+		sign = '-'
+		off = -off
+	}
+	buf := make([]byte, 0, 6)
+	buf = append(buf, sign)
+	buf = appendTwoDigits(buf, off/3600)
+	buf = append(buf, ':')
+	buf = appendTwoDigits(buf, (off%3600)/60)
+	return string(buf)
+}
+
+// parseUint reads between min and max ASCII digits from b and returns
+// their value along with the unconsumed remainder.
+func parseUint(b []byte, min, max int) (int, []byte, error) {
+	n := 0
+	v := 0
+	for n < max && n < len(b) && b[n] >= '0' && b[n] <= '9' {
+		v = v*10 + int(b[n]-'0')
+		n++
+	}
+	if n < min {
+		return 0, nil, parseErr(string(b), "expected digits")
+	}
+	return v, b[n:], nil
+}
+
+// matchName finds the longest case-sensitive match for b against names,
+// returning the matched index and unconsumed remainder.
+func matchName(b []byte, names []string) (int, []byte, error) {
+	for i, name := range names {
+		if len(b) >= len(name) && string(b[:len(name)]) == name {
+			return i, b[len(name):], nil
+		}
+	}
+	return 0, nil, parseErr(string(b), "no matching name")
+}
+
+func parseNumTZ(b []byte, colon, zuluCapable bool) (int, []byte, error) {
+	if zuluCapable && len(b) > 0 && b[0] == 'Z' {
+		return 0, b[1:], nil
+	}
+	if len(b) == 0 || (b[0] != '+' && b[0] != '-') {
+		return 0, nil, parseErr(string(b), "invalid zone offset")
+	}
+	sign := b[0]
+	rest := b[1:]
+	h, rest, err := parseUint(rest, 2, 2)
+	if err != nil {
+		return 0, nil, parseErr(string(b), "invalid zone offset hours")
+	}
+	if colon {
+		if len(rest) == 0 || rest[0] != ':' {
+			return 0, nil, parseErr(string(b), "invalid zone offset")
+		}
+		rest = rest[1:]
+	}
+	m, rest, err := parseUint(rest, 2, 2)
+	if err != nil {
+		return 0, nil, parseErr(string(b), "invalid zone offset minutes")
+	}
+	offset := h*3600 + m*60
+	if sign == '-' {
+		offset = -offset
+	}
+	return offset, rest, nil
+}
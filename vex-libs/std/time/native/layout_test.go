@@ -0,0 +1,164 @@
+package vex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLayoutFormatMatchesStdlib(t *testing.T) {
+	cases := []struct {
+		layout string
+		l      *Layout
+	}{
+		{time.RFC1123Z, RFC1123Z},
+		{time.RFC1123, RFC1123},
+		{time.RFC822, RFC822},
+		{time.RFC822Z, RFC822Z},
+		{time.Kitchen, Kitchen},
+		{time.ANSIC, ANSIC},
+	}
+	tm := time.Date(2024, 11, 7, 12, 34, 56, 0, time.FixedZone("MST", -7*3600))
+	for _, c := range cases {
+		want := tm.Format(c.layout)
+		got := c.l.Format(tm)
+		if got != want {
+			t.Errorf("Layout(%q).Format = %q, want %q", c.layout, got, want)
+		}
+	}
+}
+
+func TestLayoutParseMatchesStdlib(t *testing.T) {
+	cases := []struct {
+		layout string
+		l      *Layout
+		value  string
+	}{
+		{time.RFC1123Z, RFC1123Z, "Thu, 07 Nov 2024 12:34:56 +0000"},
+		{time.RFC822, RFC822, "07 Nov 24 12:34 UTC"},
+		{time.Kitchen, Kitchen, "3:04PM"},
+		{time.ANSIC, ANSIC, "Thu Nov  7 12:34:56 2024"},
+	}
+	for _, c := range cases {
+		want, err := time.Parse(c.layout, c.value)
+		if err != nil {
+			t.Fatalf("time.Parse(%q, %q): %v", c.layout, c.value, err)
+		}
+		got, err := c.l.Parse(c.value)
+		if err != nil {
+			t.Fatalf("Layout(%q).Parse(%q): %v", c.layout, c.value, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("Layout(%q).Parse(%q) = %v, want %v", c.layout, c.value, got, want)
+		}
+	}
+}
+
+func TestLayoutParseRejectsInvalidZoneName(t *testing.T) {
+	cases := []string{
+		"07 Nov 24 12:34 gmt",    // lower-case, stdlib requires upper-case
+		"07 Nov 24 12:34 AB",     // too short
+		"07 Nov 24 12:34 ABCDEF", // too long
+	}
+	for _, s := range cases {
+		if _, err := time.Parse(time.RFC822, s); err == nil {
+			t.Fatalf("test case %q is not actually invalid per time.Parse", s)
+		}
+		if _, err := RFC822.Parse(s); err == nil {
+			t.Errorf("RFC822.Parse(%q) = nil error, want error", s)
+		}
+	}
+}
+
+func TestLayoutParseRejectsInvalidSecond(t *testing.T) {
+	s := "Thu, 07 Nov 2024 12:34:60 MST"
+	if _, err := time.Parse(time.RFC1123, s); err == nil {
+		t.Fatalf("test case %q is not actually invalid per time.Parse", s)
+	}
+	if _, err := RFC1123.Parse(s); err == nil {
+		t.Errorf("RFC1123.Parse(%q) = nil error, want error", s)
+	}
+}
+
+func TestLayoutParseZuluCapableNumericOffset(t *testing.T) {
+	l := MustCompile(time.RFC3339)
+	cases := []struct {
+		value   string
+		wantErr bool
+	}{
+		{"2024-11-07T12:34:56Z", false},
+		{"2024-11-07T12:34:56+02:00", false},
+		{"2024-11-07T12:34:56z", true}, // time.Parse rejects lowercase z
+	}
+	for _, c := range cases {
+		want, wantErr := time.Parse(time.RFC3339, c.value)
+		got, gotErr := l.Parse(c.value)
+		if (wantErr == nil) != (gotErr == nil) {
+			t.Fatalf("Layout(RFC3339).Parse(%q) error = %v, time.Parse error = %v", c.value, gotErr, wantErr)
+		}
+		if c.wantErr && gotErr == nil {
+			t.Errorf("Layout(RFC3339).Parse(%q) = nil error, want error", c.value)
+		}
+		if wantErr == nil && !got.Equal(want) {
+			t.Errorf("Layout(RFC3339).Parse(%q) = %v, want %v", c.value, got, want)
+		}
+	}
+}
+
+func TestLayoutFormatOutOfRangeYears(t *testing.T) {
+	layout := "2006-01-02"
+	l := MustCompile(layout)
+	cases := []time.Time{
+		time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(-1, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, tm := range cases {
+		want := tm.Format(layout)
+		got := l.Format(tm)
+		if got != want {
+			t.Errorf("Layout(%q).Format(%v) = %q, want %q", layout, tm, got, want)
+		}
+	}
+}
+
+func TestLayoutParseRejectsInvalidDay(t *testing.T) {
+	cases := []string{
+		"Thu, 32 Jan 2006 15:04:05 MST", // day > 31
+		"Thu, 30 Feb 2006 15:04:05 MST", // day out of range for the month
+	}
+	for _, s := range cases {
+		if _, err := time.Parse(time.RFC1123, s); err == nil {
+			t.Fatalf("test case %q is not actually invalid per time.Parse", s)
+		}
+		if _, err := RFC1123.Parse(s); err == nil {
+			t.Errorf("RFC1123.Parse(%q) = nil error, want error", s)
+		}
+	}
+}
+
+func TestLayoutRoundTrip(t *testing.T) {
+	tm := time.Date(2024, 11, 7, 12, 34, 56, 0, time.UTC)
+	s := RFC1123Z.Format(tm)
+	got, err := RFC1123Z.Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", s, err)
+	}
+	if !got.Equal(tm) {
+		t.Errorf("round trip = %v, want %v", got, tm)
+	}
+}
+
+func BenchmarkLayoutParseRFC1123Z(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		RFC1123Z.Parse("Thu, 07 Nov 2024 12:34:56 +0000")
+	}
+}
+
+func BenchmarkLayoutFormatRFC1123Z(b *testing.B) {
+	tm := time.Date(2024, 11, 7, 12, 34, 56, 0, time.UTC)
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = RFC1123Z.AppendFormat(buf[:0], tm)
+	}
+}
@@ -0,0 +1,13 @@
+package vex
+
+// Prebuilt Layout values for the time package's common reference-time
+// formats, compiled once at package init instead of on every call the
+// way time.Parse and time.Format re-tokenize their layout argument.
+var (
+	ANSIC    = MustCompile("Mon Jan _2 15:04:05 2006")
+	RFC822   = MustCompile("02 Jan 06 15:04 MST")
+	RFC822Z  = MustCompile("02 Jan 06 15:04 -0700")
+	RFC1123  = MustCompile("Mon, 02 Jan 2006 15:04:05 MST")
+	RFC1123Z = MustCompile("Mon, 02 Jan 2006 15:04:05 -0700")
+	Kitchen  = MustCompile("3:04PM")
+)
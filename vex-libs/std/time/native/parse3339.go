@@ -0,0 +1,238 @@
+// Package vex provides allocation-conscious replacements for the hot paths
+// of the standard library's time parsing and formatting.
+package vex
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// zoneCache memoizes the *time.Location values produced for numeric
+// (non-Z) offsets so repeated parses of the same offset string (the
+// common case in log ingestion, where every line in a file shares one
+// zone) don't pay time.FixedZone's allocation on every call.
+var zoneCache sync.Map // map[string]*time.Location
+
+// ParseError reports why Parse3339 rejected a timestamp. It mirrors the
+// shape of the information time.ParseError carries without depending on
+// that unexported machinery.
+type ParseError struct {
+	Value   string
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("vex: parsing time %q: %s", e.Value, e.Message)
+}
+
+func parseErr(s, msg string) error {
+	return &ParseError{Value: s, Message: msg}
+}
+
+// digit2 decodes the two ASCII digits at b[0] and b[1] without going
+// through strconv, returning ok=false if either byte isn't '0'-'9'.
+func digit2(b []byte) (int, bool) {
+	if b[0] < '0' || b[0] > '9' || b[1] < '0' || b[1] > '9' {
+		return 0, false
+	}
+	return int(b[0]-'0')*10 + int(b[1]-'0'), true
+}
+
+// daysInMonth returns the number of days in the given month (1-12) of
+// year, used to range-check the day field the same way time.Date's
+// normalization would otherwise silently paper over (e.g. day 30 in
+// February rolling forward to March 1 instead of erroring).
+func daysInMonth(year, month int) int {
+	switch time.Month(month) {
+	case time.January, time.March, time.May, time.July, time.August, time.October, time.December:
+		return 31
+	case time.April, time.June, time.September, time.November:
+		return 30
+	case time.February:
+		if isLeapYear(year) {
+			return 29
+		}
+		return 28
+	default:
+		return 31
+	}
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+func digit4(b []byte) (int, bool) {
+	hi, ok := digit2(b[0:2])
+	if !ok {
+		return 0, false
+	}
+	lo, ok := digit2(b[2:4])
+	if !ok {
+		return 0, false
+	}
+	return hi*100 + lo, true
+}
+
+// fields3339 is the result of decoding an RFC3339/RFC3339Nano timestamp's
+// date, time and zone fields, before they've been resolved to a
+// *time.Location. Splitting decode from zone resolution lets callers
+// that need a different zone-cache strategy -- BulkParser's sharded
+// cache, for one -- reuse the byte-walking logic in decode3339.
+type fields3339 struct {
+	year, month, day int
+	hour, min, sec   int
+	nsec             int
+	isUTC            bool
+	offset           string // e.g. "+02:00"; unset when isUTC
+	offsetSeconds    int
+}
+
+// decode3339 walks s byte-by-byte validating the fixed RFC3339 separators
+// and decoding each numeric field directly, without going through
+// time.Parse's layout interpreter. It accepts the same grammar as
+// time.Parse(time.RFC3339Nano, s) -- a literal 'T' date/time separator,
+// an optional fractional-second suffix of 1-9 digits, and either a
+// literal 'Z' zone or a numeric ±HH:MM offset -- and fails in the same
+// cases, including rejecting the lowercase 't'/'z' variants time.Parse
+// itself rejects.
+func decode3339(s string) (fields3339, error) {
+	var f fields3339
+	if len(s) < 20 {
+		return f, parseErr(s, "timestamp too short")
+	}
+	b := []byte(s)
+
+	if b[4] != '-' || b[7] != '-' {
+		return f, parseErr(s, "missing date separator '-'")
+	}
+	if b[10] != 'T' {
+		return f, parseErr(s, "missing date-time separator 'T'")
+	}
+	if b[13] != ':' || b[16] != ':' {
+		return f, parseErr(s, "missing time separator ':'")
+	}
+
+	var ok bool
+	f.year, ok = digit4(b[0:4])
+	if !ok {
+		return f, parseErr(s, "invalid year")
+	}
+	f.month, ok = digit2(b[5:7])
+	if !ok {
+		return f, parseErr(s, "invalid month")
+	}
+	f.day, ok = digit2(b[8:10])
+	if !ok {
+		return f, parseErr(s, "invalid day")
+	}
+	f.hour, ok = digit2(b[11:13])
+	if !ok {
+		return f, parseErr(s, "invalid hour")
+	}
+	f.min, ok = digit2(b[14:16])
+	if !ok {
+		return f, parseErr(s, "invalid minute")
+	}
+	f.sec, ok = digit2(b[17:19])
+	if !ok {
+		return f, parseErr(s, "invalid second")
+	}
+	if f.month < 1 || f.month > 12 {
+		return f, parseErr(s, "month out of range")
+	}
+	if f.day < 1 || f.day > daysInMonth(f.year, f.month) {
+		return f, parseErr(s, "day out of range")
+	}
+	if f.hour > 23 {
+		return f, parseErr(s, "hour out of range")
+	}
+	if f.min > 59 {
+		return f, parseErr(s, "minute out of range")
+	}
+	if f.sec > 59 {
+		return f, parseErr(s, "second out of range")
+	}
+
+	i := 19
+	if i < len(b) && b[i] == '.' {
+		i++
+		start := i
+		for i < len(b) && b[i] >= '0' && b[i] <= '9' {
+			i++
+		}
+		n := i - start
+		if n == 0 {
+			return f, parseErr(s, "empty fractional second")
+		}
+		for j := 0; j < n && j < 9; j++ {
+			f.nsec = f.nsec*10 + int(b[start+j]-'0')
+		}
+		for j := n; j < 9; j++ {
+			f.nsec *= 10
+		}
+	}
+
+	if i >= len(b) {
+		return f, parseErr(s, "missing zone offset")
+	}
+
+	switch b[i] {
+	case 'Z':
+		f.isUTC = true
+		i++
+	case '+', '-':
+		if len(b)-i != 6 || b[i+3] != ':' {
+			return f, parseErr(s, "invalid zone offset")
+		}
+		offH, ok := digit2(b[i+1 : i+3])
+		if !ok {
+			return f, parseErr(s, "invalid zone offset hours")
+		}
+		offM, ok := digit2(b[i+4 : i+6])
+		if !ok {
+			return f, parseErr(s, "invalid zone offset minutes")
+		}
+		f.offsetSeconds = offH*3600 + offM*60
+		if b[i] == '-' {
+			f.offsetSeconds = -f.offsetSeconds
+		}
+		f.offset = string(b[i : i+6])
+		i += 6
+	default:
+		return f, parseErr(s, "invalid zone offset")
+	}
+
+	if i != len(b) {
+		return f, parseErr(s, "extra characters after timestamp")
+	}
+	return f, nil
+}
+
+// Parse3339 parses an RFC3339 or RFC3339Nano timestamp without going
+// through time.Parse's layout interpreter, producing the same result as
+// time.Parse(time.RFC3339Nano, s) for both well-formed and malformed
+// input.
+func Parse3339(s string) (time.Time, error) {
+	f, err := decode3339(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc := time.UTC
+	if !f.isUTC {
+		loc = cachedZone(f.offset, f.offsetSeconds)
+	}
+	return time.Date(f.year, time.Month(f.month), f.day, f.hour, f.min, f.sec, f.nsec, loc), nil
+}
+
+// cachedZone returns the *time.Location for a ±HH:MM offset string,
+// constructing it with time.FixedZone only on first use.
+func cachedZone(offset string, offsetSeconds int) *time.Location {
+	if v, ok := zoneCache.Load(offset); ok {
+		return v.(*time.Location)
+	}
+	loc := time.FixedZone(offset, offsetSeconds)
+	actual, _ := zoneCache.LoadOrStore(offset, loc)
+	return actual.(*time.Location)
+}
@@ -0,0 +1,75 @@
+package vex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse3339MatchesStdlib(t *testing.T) {
+	cases := []string{
+		"2024-11-07T12:34:56Z",
+		"2024-11-07T12:34:56.123456789Z",
+		"2024-11-07T12:34:56.123Z",
+		"2024-11-07T12:34:56.1Z",
+		"2024-11-07T12:34:56+02:00",
+		"2024-11-07T12:34:56-08:00",
+		"2024-11-07T12:34:56.123456789-08:00",
+		"2000-01-01T00:00:00.000000000Z",
+		"9999-12-31T23:59:59Z",
+	}
+	for _, s := range cases {
+		want, wantErr := time.Parse(time.RFC3339Nano, s)
+		got, gotErr := Parse3339(s)
+		if (wantErr == nil) != (gotErr == nil) {
+			t.Fatalf("Parse3339(%q) error = %v, time.Parse error = %v", s, gotErr, wantErr)
+		}
+		if wantErr == nil && !got.Equal(want) {
+			t.Fatalf("Parse3339(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestParse3339Malformed(t *testing.T) {
+	cases := []string{
+		"",
+		"2020x04-05T00:00:00Z",
+		"2020-04x05T00:00:00Z",
+		"2020-04-05X00:00:00Z",
+		"2020-04-05T00x00:00Z",
+		"2020-04-05T00:00x00Z",
+		"2020-04-05T00:00:00",
+		"2020-04-05t00:00:00Z",
+		"2020-04-05T00:00:00z",
+		"2020-04-05T00:00:00.Z",
+		"2020-04-05T00:00:00.abcZ",
+		"2020-04-05T00:00:00Y",
+		"2020-04-05T00:00:00+0200",
+		"2020-04-05T00:00:00+02:0a",
+		"2020-04-05T00:00:00+02:00extra",
+		"2020-13-05T00:00:00Z",
+		"202a-04-05T00:00:00Z",
+		"2020-02-30T00:00:00Z",
+		"2021-02-29T00:00:00Z",
+		"2020-04-31T00:00:00Z",
+		"2020-04-05T25:00:00Z",
+		"2020-04-05T00:61:00Z",
+		"2020-04-05T00:00:61Z",
+	}
+	for _, s := range cases {
+		_, wantErr := time.Parse(time.RFC3339Nano, s)
+		_, gotErr := Parse3339(s)
+		if wantErr == nil {
+			t.Fatalf("test case %q is not actually malformed per time.Parse", s)
+		}
+		if gotErr == nil {
+			t.Errorf("Parse3339(%q) = nil error, want error (time.Parse: %v)", s, wantErr)
+		}
+	}
+}
+
+func BenchmarkParse3339(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Parse3339("2024-11-07T12:34:56.123456789Z")
+	}
+}